@@ -3,19 +3,35 @@ package commands
 import (
 	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
 	"text/tabwriter"
+	"time"
 
 	argocdclient "github.com/argoproj/argo-cd/client"
 	"github.com/argoproj/argo-cd/errors"
 	argoappv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/argoproj/argo-cd/pkg/pb"
 	"github.com/argoproj/argo-cd/server/application"
 	"github.com/argoproj/argo-cd/util"
+	"github.com/argoproj/argo-cd/util/printers"
+	"github.com/fatih/color"
 	"github.com/ghodss/yaml"
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// podColors is the palette cycled through to distinguish pods in multiplexed log output
+var podColors = []color.Attribute{color.FgCyan, color.FgMagenta, color.FgYellow, color.FgGreen, color.FgBlue, color.FgRed}
+
 // NewApplicationCommand returns a new instance of an `argocd app` command
 func NewApplicationCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 	var command = &cobra.Command{
@@ -32,6 +48,9 @@ func NewApplicationCommand(clientOpts *argocdclient.ClientOptions) *cobra.Comman
 	command.AddCommand(NewApplicationSyncCommand(clientOpts))
 	command.AddCommand(NewApplicationListCommand(clientOpts))
 	command.AddCommand(NewApplicationRemoveCommand(clientOpts))
+	command.AddCommand(NewApplicationLogsCommand(clientOpts))
+	command.AddCommand(NewApplicationHistoryCommand(clientOpts))
+	command.AddCommand(NewApplicationRollbackCommand(clientOpts))
 	return command
 }
 
@@ -41,11 +60,26 @@ func NewApplicationAddCommand(clientOpts *argocdclient.ClientOptions) *cobra.Com
 		repoURL string
 		appPath string
 		env     string
+		file    string
+		upsert  bool
 	)
 	var command = &cobra.Command{
 		Use:   "add",
 		Short: fmt.Sprintf("%s app add APPNAME", cliName),
 		Run: func(c *cobra.Command, args []string) {
+			conn, appIf := argocdclient.NewClient(clientOpts).NewApplicationClientOrDie()
+			defer util.Close(conn)
+
+			if file != "" {
+				if len(args) != 0 {
+					c.HelpFunc()(c, args)
+					os.Exit(1)
+				}
+				apps, err := readApplications(file)
+				errors.CheckError(err)
+				os.Exit(addApplications(appIf, apps, upsert))
+			}
+
 			if len(args) != 1 {
 				c.HelpFunc()(c, args)
 				os.Exit(1)
@@ -62,21 +96,209 @@ func NewApplicationAddCommand(clientOpts *argocdclient.ClientOptions) *cobra.Com
 					},
 				},
 			}
-			conn, appIf := argocdclient.NewClient(clientOpts).NewApplicationClientOrDie()
-			defer util.Close(conn)
-			_, err := appIf.Create(context.Background(), &app)
-			errors.CheckError(err)
+			os.Exit(addApplications(appIf, []argoappv1.Application{app}, upsert))
 		},
 	}
 	command.Flags().StringVar(&repoURL, "repo", "", "Repository URL")
 	command.Flags().StringVar(&appPath, "path", "", "Path in repository to the ksonnet app directory")
 	command.Flags().StringVar(&env, "env", "", "Application environment to monitor")
+	command.Flags().StringVarP(&file, "file", "f", "", "Create applications from a YAML/JSON file, or - to read from stdin, containing one or more Applications separated by '---'")
+	command.Flags().BoolVar(&upsert, "upsert", false, "Update the application if it already exists")
+
+	command.AddCommand(newApplicationAddHelmCommand(clientOpts))
+	command.AddCommand(newApplicationAddKustomizeCommand(clientOpts))
+	command.AddCommand(newApplicationAddDirectoryCommand(clientOpts))
 
 	return command
 }
 
+// newApplicationAddHelmCommand returns a new instance of an `argocd app add helm` command
+func newApplicationAddHelmCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		repoURL     string
+		appPath     string
+		releaseName string
+		valueFiles  []string
+		upsert      bool
+	)
+	var command = &cobra.Command{
+		Use:   "helm APPNAME",
+		Short: fmt.Sprintf("%s app add helm APPNAME", cliName),
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			app := argoappv1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: args[0]},
+				Spec: argoappv1.ApplicationSpec{
+					Source: argoappv1.ApplicationSource{
+						RepoURL: repoURL,
+						Path:    appPath,
+						Helm: &argoappv1.HelmSource{
+							ReleaseName: releaseName,
+							ValueFiles:  valueFiles,
+						},
+					},
+				},
+			}
+			conn, appIf := argocdclient.NewClient(clientOpts).NewApplicationClientOrDie()
+			defer util.Close(conn)
+			os.Exit(addApplications(appIf, []argoappv1.Application{app}, upsert))
+		},
+	}
+	command.Flags().StringVar(&repoURL, "repo", "", "Repository URL")
+	command.Flags().StringVar(&appPath, "path", "", "Path in repository to the Helm chart")
+	command.Flags().StringVar(&releaseName, "release-name", "", "Helm release name")
+	command.Flags().StringArrayVar(&valueFiles, "values", nil, "Helm values file(s), relative to the chart path")
+	command.Flags().BoolVar(&upsert, "upsert", false, "Update the application if it already exists")
+	return command
+}
+
+// newApplicationAddKustomizeCommand returns a new instance of an `argocd app add kustomize` command
+func newApplicationAddKustomizeCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		repoURL    string
+		appPath    string
+		namePrefix string
+		images     []string
+		upsert     bool
+	)
+	var command = &cobra.Command{
+		Use:   "kustomize APPNAME",
+		Short: fmt.Sprintf("%s app add kustomize APPNAME", cliName),
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			app := argoappv1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: args[0]},
+				Spec: argoappv1.ApplicationSpec{
+					Source: argoappv1.ApplicationSource{
+						RepoURL: repoURL,
+						Path:    appPath,
+						Kustomize: &argoappv1.KustomizeSource{
+							NamePrefix: namePrefix,
+							Images:     images,
+						},
+					},
+				},
+			}
+			conn, appIf := argocdclient.NewClient(clientOpts).NewApplicationClientOrDie()
+			defer util.Close(conn)
+			os.Exit(addApplications(appIf, []argoappv1.Application{app}, upsert))
+		},
+	}
+	command.Flags().StringVar(&repoURL, "repo", "", "Repository URL")
+	command.Flags().StringVar(&appPath, "path", "", "Path in repository to the Kustomize overlay")
+	command.Flags().StringVar(&namePrefix, "name-prefix", "", "Prefix to prepend to resource names")
+	command.Flags().StringArrayVar(&images, "image", nil, "Image override, e.g. --image nginx=nginx:1.15")
+	command.Flags().BoolVar(&upsert, "upsert", false, "Update the application if it already exists")
+	return command
+}
+
+// newApplicationAddDirectoryCommand returns a new instance of an `argocd app add directory` command
+func newApplicationAddDirectoryCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		repoURL string
+		appPath string
+		recurse bool
+		jsonnet bool
+		upsert  bool
+	)
+	var command = &cobra.Command{
+		Use:   "directory APPNAME",
+		Short: fmt.Sprintf("%s app add directory APPNAME", cliName),
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			app := argoappv1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: args[0]},
+				Spec: argoappv1.ApplicationSpec{
+					Source: argoappv1.ApplicationSource{
+						RepoURL: repoURL,
+						Path:    appPath,
+						Directory: &argoappv1.DirectorySource{
+							Recurse: recurse,
+							Jsonnet: jsonnet,
+						},
+					},
+				},
+			}
+			conn, appIf := argocdclient.NewClient(clientOpts).NewApplicationClientOrDie()
+			defer util.Close(conn)
+			os.Exit(addApplications(appIf, []argoappv1.Application{app}, upsert))
+		},
+	}
+	command.Flags().StringVar(&repoURL, "repo", "", "Repository URL")
+	command.Flags().StringVar(&appPath, "path", "", "Path in repository to the manifest directory")
+	command.Flags().BoolVar(&recurse, "recurse", false, "Recurse into sub-directories")
+	command.Flags().BoolVar(&jsonnet, "jsonnet", false, "Treat *.jsonnet files in the directory as manifests")
+	command.Flags().BoolVar(&upsert, "upsert", false, "Update the application if it already exists")
+	return command
+}
+
+// readApplications parses one or more '---' separated argoappv1.Application manifests
+// from the given path, or from stdin when path is "-".
+func readApplications(path string) ([]argoappv1.Application, error) {
+	var r io.Reader
+	if path == "-" {
+		r = os.Stdin
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var apps []argoappv1.Application
+	for _, doc := range strings.Split(string(data), "\n---") {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		var app argoappv1.Application
+		if err := yaml.Unmarshal([]byte(doc), &app); err != nil {
+			return nil, fmt.Errorf("failed to parse application manifest: %v", err)
+		}
+		apps = append(apps, app)
+	}
+	return apps, nil
+}
+
+// addApplications creates (or, with upsert, updates) each of the given applications,
+// printing a per-application error for any that fail and returning a process exit code.
+func addApplications(appIf application.ApplicationServiceClient, apps []argoappv1.Application, upsert bool) int {
+	exitCode := 0
+	for _, app := range apps {
+		verb := "created"
+		_, err := appIf.Create(context.Background(), &app)
+		if err != nil && upsert && status.Code(err) == codes.AlreadyExists {
+			verb = "updated"
+			_, err = appIf.Update(context.Background(), &app)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", app.Name, err)
+			exitCode = 1
+			continue
+		}
+		fmt.Printf("application %q %s\n", app.Name, verb)
+	}
+	return exitCode
+}
+
 // NewApplicationGetCommand returns a new instance of an `argocd app get` command
 func NewApplicationGetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		output string
+	)
 	var command = &cobra.Command{
 		Use:   "get",
 		Short: fmt.Sprintf("%s app get APPNAME", cliName),
@@ -90,12 +312,11 @@ func NewApplicationGetCommand(clientOpts *argocdclient.ClientOptions) *cobra.Com
 			for _, appName := range args {
 				app, err := appIf.Get(context.Background(), &application.ApplicationQuery{Name: appName})
 				errors.CheckError(err)
-				yamlBytes, err := yaml.Marshal(app)
-				errors.CheckError(err)
-				fmt.Printf("%v\n", string(yamlBytes))
+				errors.CheckError(printers.PrintApplication(os.Stdout, output, app))
 			}
 		},
 	}
+	command.Flags().StringVarP(&output, "output", "o", "", "Output format. One of: yaml|json|name")
 	return command
 }
 
@@ -122,29 +343,34 @@ func NewApplicationRemoveCommand(clientOpts *argocdclient.ClientOptions) *cobra.
 
 // NewApplicationListCommand returns a new instance of an `argocd app rm` command
 func NewApplicationListCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		output   string
+		selector string
+	)
 	var command = &cobra.Command{
 		Use:   "list",
 		Short: fmt.Sprintf("%s app list", cliName),
 		Run: func(c *cobra.Command, args []string) {
 			conn, appIf := argocdclient.NewClient(clientOpts).NewApplicationClientOrDie()
 			defer util.Close(conn)
-			apps, err := appIf.List(context.Background(), &application.ApplicationQuery{})
+			apps, err := appIf.List(context.Background(), &application.ApplicationQuery{Selector: selector})
 			errors.CheckError(err)
-			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-			fmt.Fprintf(w, "NAME\tCLUSTER\tNAMESPACE\tSTATUS\n")
-			for _, app := range apps.Items {
-				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", app.Name, app.Status.ComparisonResult.Server, app.Status.ComparisonResult.Namespace, app.Status.ComparisonResult.Status)
-			}
-			_ = w.Flush()
+			errors.CheckError(printers.PrintApplicationList(os.Stdout, output, apps.Items))
 		},
 	}
+	command.Flags().StringVarP(&output, "output", "o", "", "Output format. One of: wide|json|yaml|name")
+	command.Flags().StringVarP(&selector, "selector", "l", "", "Label selector to filter applications")
 	return command
 }
 
 // NewApplicationSyncCommand returns a new instance of an `argocd app sync` command
 func NewApplicationSyncCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
 	var (
-		dryRun bool
+		dryRun     bool
+		prune      bool
+		wait       bool
+		noProgress bool
+		timeout    uint
 	)
 	var command = &cobra.Command{
 		Use:   "sync",
@@ -158,20 +384,197 @@ func NewApplicationSyncCommand(clientOpts *argocdclient.ClientOptions) *cobra.Co
 			defer util.Close(conn)
 			appName := args[0]
 			syncReq := application.ApplicationSyncRequest{
+				Name:           appName,
+				DryRun:         dryRun,
+				Prune:          prune,
+				Wait:           wait,
+				TimeoutSeconds: int64(timeout),
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			sigCh := make(chan os.Signal, 1)
+			signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+			go func() {
+				<-sigCh
+				fmt.Println("\nAborting sync...")
+				cancel()
+			}()
+			defer signal.Stop(sigCh)
+
+			stream, err := appIf.SyncStream(ctx, &syncReq)
+			errors.CheckError(err)
+
+			renderProgress := !noProgress && isatty.IsTerminal(os.Stdout.Fd())
+			var bar *pb.ProgressBar
+			started := map[string]bool{}
+			failed := false
+			for {
+				status, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if status != nil && status.Phase == application.ResourcePhase_Failed {
+					failed = true
+				}
+				errors.CheckError(err)
+				if renderProgress {
+					if bar == nil {
+						bar = pb.New()
+					}
+					name := fmt.Sprintf("%s %s", status.Kind, status.Name)
+					switch status.Phase {
+					case application.ResourcePhase_Running:
+						started[name] = true
+						bar.Start(name)
+					case application.ResourcePhase_Synced, application.ResourcePhase_Failed:
+						// an aborted resource is reported Failed without ever having been Running
+						if !started[name] {
+							bar.Start(name)
+						}
+						bar.Finish(status.Phase == application.ResourcePhase_Failed)
+					}
+				} else {
+					fmt.Printf("%-10s %-10s %s\n", status.Phase, status.Kind, status.Name)
+				}
+			}
+			if bar != nil {
+				bar.Close()
+			}
+			if failed {
+				fmt.Println("Sync failed")
+				os.Exit(1)
+			}
+		},
+	}
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "Preview apply without affecting cluster")
+	command.Flags().BoolVar(&prune, "prune", false, "Allow deleting resources no longer tracked by the application")
+	command.Flags().BoolVar(&wait, "wait", false, "Block until the application reports Healthy")
+	command.Flags().BoolVar(&noProgress, "no-progress", false, "Disable the live progress bar even when attached to a TTY")
+	command.Flags().UintVar(&timeout, "timeout", 0, "Time out the sync after this many seconds (0 never times out)")
+	return command
+}
+
+// NewApplicationLogsCommand returns a new instance of an `argocd app logs` command
+func NewApplicationLogsCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		follow    bool
+		tail      int64
+		since     time.Duration
+		container string
+	)
+	var command = &cobra.Command{
+		Use:   "logs APPNAME [RESOURCE]",
+		Short: fmt.Sprintf("%s app logs APPNAME [RESOURCE]", cliName),
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) == 0 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			appName := args[0]
+			var podName string
+			if len(args) > 1 {
+				podName = args[1]
+			}
+			conn, appIf := argocdclient.NewClient(clientOpts).NewApplicationClientOrDie()
+			defer util.Close(conn)
+			query := application.ApplicationLogsQuery{
+				Name:      appName,
+				PodName:   podName,
+				Container: container,
+				Follow:    follow,
+				TailLines: tail,
+			}
+			if since > 0 {
+				query.SinceSeconds = int64(since.Seconds())
+			}
+			stream, err := appIf.Logs(context.Background(), &query)
+			errors.CheckError(err)
+			colorize := isatty.IsTerminal(os.Stdout.Fd())
+			podColor := map[string]color.Attribute{}
+			for {
+				entry, err := stream.Recv()
+				if err == io.EOF {
+					return
+				}
+				errors.CheckError(err)
+				prefix := fmt.Sprintf("[%s/%s]", entry.Pod, entry.Container)
+				if colorize {
+					attr, ok := podColor[entry.Pod]
+					if !ok {
+						attr = podColors[len(podColor)%len(podColors)]
+						podColor[entry.Pod] = attr
+					}
+					prefix = color.New(attr).Sprint(prefix)
+				}
+				fmt.Printf("%s %s\n", prefix, entry.Content)
+			}
+		},
+	}
+	command.Flags().BoolVarP(&follow, "follow", "f", false, "Specify if the logs should be streamed")
+	command.Flags().Int64Var(&tail, "tail", 0, "The number of lines from the end of the logs to show")
+	command.Flags().DurationVar(&since, "since", 0, "Only return logs newer than a relative duration")
+	command.Flags().StringVar(&container, "container", "", "Print the logs of this container")
+	return command
+}
+
+// NewApplicationHistoryCommand returns a new instance of an `argocd app history` command
+func NewApplicationHistoryCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "history APPNAME",
+		Short: fmt.Sprintf("%s app history APPNAME", cliName),
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 1 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			conn, appIf := argocdclient.NewClient(clientOpts).NewApplicationClientOrDie()
+			defer util.Close(conn)
+			app, err := appIf.Get(context.Background(), &application.ApplicationQuery{Name: args[0]})
+			errors.CheckError(err)
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintf(w, "ID\tDEPLOYED\tREVISION\n")
+			for _, h := range app.Status.History {
+				fmt.Fprintf(w, "%d\t%s\t%s\n", h.ID, h.DeployedAt.Time.Format(time.RFC3339), h.Revision)
+			}
+			_ = w.Flush()
+		},
+	}
+	return command
+}
+
+// NewApplicationRollbackCommand returns a new instance of an `argocd app rollback` command
+func NewApplicationRollbackCommand(clientOpts *argocdclient.ClientOptions) *cobra.Command {
+	var (
+		dryRun bool
+	)
+	var command = &cobra.Command{
+		Use:   "rollback APPNAME ID",
+		Short: fmt.Sprintf("%s app rollback APPNAME ID", cliName),
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) != 2 {
+				c.HelpFunc()(c, args)
+				os.Exit(1)
+			}
+			appName := args[0]
+			id, err := strconv.ParseInt(args[1], 10, 64)
+			errors.CheckError(err)
+			conn, appIf := argocdclient.NewClient(clientOpts).NewApplicationClientOrDie()
+			defer util.Close(conn)
+			rollbackRes, err := appIf.Rollback(context.Background(), &application.RollbackRequest{
 				Name:   appName,
+				Id:     id,
 				DryRun: dryRun,
-			}
-			syncRes, err := appIf.Sync(context.Background(), &syncReq)
+			})
 			errors.CheckError(err)
-			fmt.Printf("%s %s\n", appName, syncRes.Message)
+			fmt.Printf("%s %s\n", appName, rollbackRes.Message)
 			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 			fmt.Fprintf(w, "NAME\tKIND\tMESSAGE\n")
-			for _, resDetails := range syncRes.Resources {
+			for _, resDetails := range rollbackRes.Resources {
 				fmt.Fprintf(w, "%s\t%s\t%s\n", resDetails.Name, resDetails.Kind, resDetails.Message)
 			}
 			_ = w.Flush()
 		},
 	}
-	command.Flags().BoolVar(&dryRun, "dry-run", false, "Preview apply without affecting cluster")
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "Preview the rollback without affecting the cluster")
 	return command
-}
\ No newline at end of file
+}
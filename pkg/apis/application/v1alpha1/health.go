@@ -0,0 +1,16 @@
+package v1alpha1
+
+// HealthStatusCode is the observed health of an Application or one of its resources
+type HealthStatusCode string
+
+const (
+	HealthStatusUnknown     HealthStatusCode = "Unknown"
+	HealthStatusProgressing HealthStatusCode = "Progressing"
+	HealthStatusHealthy     HealthStatusCode = "Healthy"
+	HealthStatusDegraded    HealthStatusCode = "Degraded"
+)
+
+// HealthStatus holds the health of an Application as last observed by the controller
+type HealthStatus struct {
+	Status HealthStatusCode `json:"status,omitempty" protobuf:"bytes,1,opt,name=status"`
+}
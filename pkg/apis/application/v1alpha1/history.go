@@ -0,0 +1,38 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// RevisionHistoryLimit is the maximum number of RevisionHistory entries retained per
+// application. Older entries are evicted as new ones are appended.
+const RevisionHistoryLimit = 10
+
+// RevisionHistory is a record of a previously deployed revision of an application
+type RevisionHistory struct {
+	// ID is a sequence number, monotonically increasing across the lifetime of the application
+	ID int64 `json:"id" protobuf:"varint,1,opt,name=id"`
+	// Revision holds the revision the application was synced to (e.g. a git commit SHA)
+	Revision string `json:"revision" protobuf:"bytes,2,opt,name=revision"`
+	// DeployedAt is the time this revision was successfully deployed
+	DeployedAt metav1.Time `json:"deployedAt" protobuf:"bytes,3,opt,name=deployedAt"`
+	// Source is the application source used to generate the deployed manifests
+	Source ApplicationSource `json:"source" protobuf:"bytes,4,opt,name=source"`
+}
+
+// AppendHistory appends a new RevisionHistory entry to the application's status,
+// assigning it the next sequence ID and evicting the oldest entry once
+// RevisionHistoryLimit is exceeded.
+func (status *ApplicationStatus) AppendHistory(source ApplicationSource, revision string) {
+	nextID := int64(0)
+	if l := len(status.History); l > 0 {
+		nextID = status.History[l-1].ID + 1
+	}
+	status.History = append(status.History, RevisionHistory{
+		ID:         nextID,
+		Revision:   revision,
+		DeployedAt: metav1.Now(),
+		Source:     source,
+	})
+	if len(status.History) > RevisionHistoryLimit {
+		status.History = status.History[len(status.History)-RevisionHistoryLimit:]
+	}
+}
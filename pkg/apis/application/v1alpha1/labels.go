@@ -0,0 +1,7 @@
+package v1alpha1
+
+// ResourceInstanceLabelKey is applied by the controller to every live cluster resource an
+// Application manages, with the Application's name as the value. It lets anything that
+// needs "every resource owned by this Application" (pruning, `app logs`) select on it
+// instead of listing a whole namespace.
+const ResourceInstanceLabelKey = "argocd.argoproj.io/instance"
@@ -0,0 +1,90 @@
+package v1alpha1
+
+import "fmt"
+
+// ApplicationSourceType is the manifest generator used to render an ApplicationSource
+type ApplicationSourceType string
+
+const (
+	ApplicationSourceTypeKsonnet   ApplicationSourceType = "Ksonnet"
+	ApplicationSourceTypeHelm      ApplicationSourceType = "Helm"
+	ApplicationSourceTypeKustomize ApplicationSourceType = "Kustomize"
+	ApplicationSourceTypeDirectory ApplicationSourceType = "Directory"
+)
+
+// HelmParameter is a parameter passed to `helm template` as --set NAME=VALUE
+type HelmParameter struct {
+	Name  string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	Value string `json:"value" protobuf:"bytes,2,opt,name=value"`
+}
+
+// KsonnetSource holds options specific to a ksonnet application directory
+type KsonnetSource struct {
+	// Environment is the ksonnet environment to use for manifest generation
+	Environment string `json:"environment" protobuf:"bytes,1,opt,name=environment"`
+}
+
+// HelmSource holds options specific to a Helm chart
+type HelmSource struct {
+	ValueFiles  []string        `json:"valueFiles,omitempty" protobuf:"bytes,1,rep,name=valueFiles"`
+	Parameters  []HelmParameter `json:"parameters,omitempty" protobuf:"bytes,2,rep,name=parameters"`
+	ReleaseName string          `json:"releaseName,omitempty" protobuf:"bytes,3,opt,name=releaseName"`
+}
+
+// KustomizeSource holds options specific to a Kustomize overlay
+type KustomizeSource struct {
+	NamePrefix string   `json:"namePrefix,omitempty" protobuf:"bytes,1,opt,name=namePrefix"`
+	Images     []string `json:"images,omitempty" protobuf:"bytes,2,rep,name=images"`
+}
+
+// DirectorySource holds options specific to a plain directory of Kubernetes YAML/Jsonnet
+type DirectorySource struct {
+	Recurse bool `json:"recurse,omitempty" protobuf:"varint,1,opt,name=recurse"`
+	Jsonnet bool `json:"jsonnet,omitempty" protobuf:"varint,2,opt,name=jsonnet"`
+}
+
+// ApplicationSource contains the location of an application's manifests, plus at most one
+// of Ksonnet/Helm/Kustomize/Directory describing how those manifests should be generated.
+// When none is set, the type is auto-detected from the repository contents.
+type ApplicationSource struct {
+	// RepoURL is the git repository URL that contains the application manifests
+	RepoURL string `json:"repoURL" protobuf:"bytes,1,opt,name=repoURL"`
+	// Path is the directory within the repository holding the application manifests
+	Path string `json:"path" protobuf:"bytes,2,opt,name=path"`
+	// TargetRevision is the git revision (branch, tag, or commit SHA) to sync to
+	TargetRevision string `json:"targetRevision,omitempty" protobuf:"bytes,3,opt,name=targetRevision"`
+
+	// Environment is deprecated in favor of Ksonnet.Environment; still honored so existing
+	// Application manifests authored before the source type refactor continue to work.
+	Environment string `json:"environment,omitempty" protobuf:"bytes,4,opt,name=environment"`
+
+	Ksonnet   *KsonnetSource   `json:"ksonnet,omitempty" protobuf:"bytes,5,opt,name=ksonnet"`
+	Helm      *HelmSource      `json:"helm,omitempty" protobuf:"bytes,6,opt,name=helm"`
+	Kustomize *KustomizeSource `json:"kustomize,omitempty" protobuf:"bytes,7,opt,name=kustomize"`
+	Directory *DirectorySource `json:"directory,omitempty" protobuf:"bytes,8,opt,name=directory"`
+}
+
+// ExplicitType returns the source type that was explicitly set on the source, if any.
+// It does not inspect repository contents; that auto-detection lives in the reposerver.
+func (s ApplicationSource) ExplicitType() (ApplicationSourceType, error) {
+	set := []ApplicationSourceType{}
+	if s.Ksonnet != nil || s.Environment != "" {
+		set = append(set, ApplicationSourceTypeKsonnet)
+	}
+	if s.Helm != nil {
+		set = append(set, ApplicationSourceTypeHelm)
+	}
+	if s.Kustomize != nil {
+		set = append(set, ApplicationSourceTypeKustomize)
+	}
+	if s.Directory != nil {
+		set = append(set, ApplicationSourceTypeDirectory)
+	}
+	if len(set) > 1 {
+		return "", fmt.Errorf("application source has more than one type set: %v", set)
+	}
+	if len(set) == 0 {
+		return "", nil
+	}
+	return set[0], nil
+}
@@ -0,0 +1,54 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ComparisonResult holds the result of the last comparison between the desired state
+// described by an Application's source and the live state of its target cluster/namespace
+type ComparisonResult struct {
+	// Server is the Kubernetes API server URL of the target cluster
+	Server string `json:"server,omitempty" protobuf:"bytes,1,opt,name=server"`
+	// Namespace is the target namespace the application's resources are deployed into
+	Namespace string `json:"namespace,omitempty" protobuf:"bytes,2,opt,name=namespace"`
+	// Status is a short human-readable summary of the comparison (e.g. "Synced", "OutOfSync")
+	Status string `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+	// Revision is the resolved revision (e.g. git commit SHA) the comparison was made against
+	Revision string `json:"revision,omitempty" protobuf:"bytes,4,opt,name=revision"`
+}
+
+// ApplicationSpec is the desired state of an Application
+type ApplicationSpec struct {
+	// Source describes where the application's manifests live and how to generate them
+	Source ApplicationSource `json:"source" protobuf:"bytes,1,opt,name=source"`
+	// Project restricts which repositories/clusters the application may use
+	Project string `json:"project,omitempty" protobuf:"bytes,2,opt,name=project"`
+}
+
+// ApplicationStatus is the observed state of an Application, as last reported by the
+// application controller
+type ApplicationStatus struct {
+	// ComparisonResult is the result of the most recent comparison
+	ComparisonResult ComparisonResult `json:"comparisonResult,omitempty" protobuf:"bytes,1,opt,name=comparisonResult"`
+	// Health is the observed health of the application
+	Health HealthStatus `json:"health,omitempty" protobuf:"bytes,2,opt,name=health"`
+	// History is the list of previously deployed revisions, most recent last
+	History []RevisionHistory `json:"history,omitempty" protobuf:"bytes,3,rep,name=history"`
+}
+
+// Application is a definition of an Application resource
+type Application struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Spec   ApplicationSpec   `json:"spec" protobuf:"bytes,2,opt,name=spec"`
+	Status ApplicationStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+}
+
+// ApplicationList is a list of Application resources
+type ApplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Items []Application `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
@@ -0,0 +1,56 @@
+// Package pb implements a minimal terminal progress bar for driving
+// long-running, multi-resource operations such as `argocd app sync`.
+package pb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ProgressBar renders a single-line, redrawing progress bar of named steps moving
+// through a small set of states. The total number of steps isn't known up front when
+// driven by a streaming RPC, so it grows as steps are Started and shrinks the
+// "remaining" portion of the bar as they Finish.
+type ProgressBar struct {
+	total   int
+	done    int
+	failed  int
+	current string
+}
+
+// New returns a ProgressBar with no steps yet known.
+func New() *ProgressBar {
+	return &ProgressBar{}
+}
+
+// Start records that a new step has begun, showing name as the in-flight step.
+func (p *ProgressBar) Start(name string) {
+	p.total++
+	p.current = name
+	p.render()
+}
+
+// Finish marks the in-flight step as having reached a terminal state and redraws the bar.
+func (p *ProgressBar) Finish(failed bool) {
+	p.done++
+	if failed {
+		p.failed++
+	}
+	p.render()
+}
+
+func (p *ProgressBar) render() {
+	width := 30
+	filled := 0
+	if p.total > 0 {
+		filled = width * p.done / p.total
+	}
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", width-filled)
+	fmt.Printf("\r[%s] %d/%d %-40s", bar, p.done, p.total, p.current)
+}
+
+// Close finishes the progress bar, printing a trailing newline so subsequent output
+// doesn't overwrite the final redraw.
+func (p *ProgressBar) Close() {
+	fmt.Println()
+}
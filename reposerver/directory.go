@@ -0,0 +1,77 @@
+package reposerver
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	argoappv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// DirectoryGenerator renders manifests from a plain directory of Kubernetes YAML (or
+// Jsonnet, when source.Directory.Jsonnet is set), optionally recursing into
+// sub-directories.
+type DirectoryGenerator struct{}
+
+func (g *DirectoryGenerator) Generate(repoDir string, source argoappv1.ApplicationSource) ([]unstructured.Unstructured, error) {
+	recurse := source.Directory != nil && source.Directory.Recurse
+	jsonnet := source.Directory != nil && source.Directory.Jsonnet
+
+	var objs []unstructured.Unstructured
+	files, err := findManifests(repoDir, recurse, jsonnet)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		var out []byte
+		if jsonnet {
+			cmd := exec.Command("jsonnet", file)
+			out, err = cmd.Output()
+		} else {
+			out, err = ioutil.ReadFile(file)
+		}
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := splitYAML(out)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, parsed...)
+	}
+	return objs, nil
+}
+
+// findManifests returns the YAML files (or, when jsonnet is true, the Jsonnet files) directly
+// under dir, or under the full subtree of dir when recurse is true.
+func findManifests(dir string, recurse, jsonnet bool) ([]string, error) {
+	var files []string
+	walkFn := func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if !recurse && path != dir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if jsonnet {
+			if strings.HasSuffix(path, ".jsonnet") {
+				files = append(files, path)
+			}
+			return nil
+		}
+		if strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml") {
+			files = append(files, path)
+		}
+		return nil
+	}
+	if err := filepath.Walk(dir, walkFn); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
@@ -0,0 +1,86 @@
+// Package reposerver renders the Kubernetes manifests for an ApplicationSource checked
+// out from git, dispatching to a ManifestGenerator based on the source's type.
+package reposerver
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	argoappv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/ghodss/yaml"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ManifestGenerator produces the Kubernetes manifests for an ApplicationSource checked
+// out at repoDir.
+type ManifestGenerator interface {
+	Generate(repoDir string, source argoappv1.ApplicationSource) ([]unstructured.Unstructured, error)
+}
+
+// generators maps each source type to the ManifestGenerator that handles it
+var generators = map[argoappv1.ApplicationSourceType]ManifestGenerator{
+	argoappv1.ApplicationSourceTypeKsonnet:   &KsonnetGenerator{},
+	argoappv1.ApplicationSourceTypeHelm:      &HelmGenerator{},
+	argoappv1.ApplicationSourceTypeKustomize: &KustomizeGenerator{},
+	argoappv1.ApplicationSourceTypeDirectory: &DirectoryGenerator{},
+}
+
+// GenerateManifests renders the manifests for source, checked out at repoDir, dispatching
+// to the generator for source's explicit type, or auto-detecting one from repoDir's
+// contents when no type was set.
+func GenerateManifests(repoDir string, source argoappv1.ApplicationSource) ([]unstructured.Unstructured, error) {
+	sourceType, err := source.ExplicitType()
+	if err != nil {
+		return nil, err
+	}
+	if sourceType == "" {
+		sourceType, err = detectSourceType(repoDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+	generator, ok := generators[sourceType]
+	if !ok {
+		return nil, fmt.Errorf("no manifest generator registered for source type %q", sourceType)
+	}
+	return generator.Generate(repoDir, source)
+}
+
+// detectSourceType infers the manifest generator to use from well-known files present in
+// the application's directory within the checked-out repository.
+func detectSourceType(appDir string) (argoappv1.ApplicationSourceType, error) {
+	switch {
+	case fileExists(filepath.Join(appDir, "Chart.yaml")):
+		return argoappv1.ApplicationSourceTypeHelm, nil
+	case fileExists(filepath.Join(appDir, "kustomization.yaml")):
+		return argoappv1.ApplicationSourceTypeKustomize, nil
+	case fileExists(filepath.Join(appDir, "app.yaml")):
+		return argoappv1.ApplicationSourceTypeKsonnet, nil
+	default:
+		return argoappv1.ApplicationSourceTypeDirectory, nil
+	}
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// splitYAML parses a `---`-delimited stream of YAML documents into unstructured objects,
+// skipping empty documents.
+func splitYAML(out []byte) ([]unstructured.Unstructured, error) {
+	var objs []unstructured.Unstructured
+	for _, doc := range bytes.Split(out, []byte("\n---")) {
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		var m map[string]interface{}
+		if err := yaml.Unmarshal(doc, &m); err != nil {
+			return nil, err
+		}
+		objs = append(objs, unstructured.Unstructured{Object: m})
+	}
+	return objs, nil
+}
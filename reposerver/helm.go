@@ -0,0 +1,33 @@
+package reposerver
+
+import (
+	"fmt"
+	"os/exec"
+
+	argoappv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// HelmGenerator renders manifests from a Helm chart via `helm template`.
+type HelmGenerator struct{}
+
+func (g *HelmGenerator) Generate(repoDir string, source argoappv1.ApplicationSource) ([]unstructured.Unstructured, error) {
+	args := []string{"template", repoDir}
+	if source.Helm != nil {
+		if source.Helm.ReleaseName != "" {
+			args = append(args, "--name", source.Helm.ReleaseName)
+		}
+		for _, valueFile := range source.Helm.ValueFiles {
+			args = append(args, "-f", valueFile)
+		}
+		for _, p := range source.Helm.Parameters {
+			args = append(args, "--set", fmt.Sprintf("%s=%s", p.Name, p.Value))
+		}
+	}
+	cmd := exec.Command("helm", args...)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	return splitYAML(out)
+}
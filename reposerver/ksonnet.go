@@ -0,0 +1,26 @@
+package reposerver
+
+import (
+	"os/exec"
+
+	argoappv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// KsonnetGenerator renders manifests from a ksonnet application directory via
+// `ks show ENVIRONMENT`.
+type KsonnetGenerator struct{}
+
+func (g *KsonnetGenerator) Generate(repoDir string, source argoappv1.ApplicationSource) ([]unstructured.Unstructured, error) {
+	env := source.Environment
+	if source.Ksonnet != nil {
+		env = source.Ksonnet.Environment
+	}
+	cmd := exec.Command("ks", "show", env)
+	cmd.Dir = repoDir
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	return splitYAML(out)
+}
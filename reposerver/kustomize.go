@@ -0,0 +1,39 @@
+package reposerver
+
+import (
+	"os/exec"
+
+	argoappv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// KustomizeGenerator renders manifests from a Kustomize overlay via `kustomize build`,
+// first applying any requested name prefix / image overrides with `kustomize edit`.
+type KustomizeGenerator struct{}
+
+func (g *KustomizeGenerator) Generate(repoDir string, source argoappv1.ApplicationSource) ([]unstructured.Unstructured, error) {
+	if source.Kustomize != nil {
+		if source.Kustomize.NamePrefix != "" {
+			if err := runIn(repoDir, "kustomize", "edit", "set", "nameprefix", source.Kustomize.NamePrefix); err != nil {
+				return nil, err
+			}
+		}
+		for _, image := range source.Kustomize.Images {
+			if err := runIn(repoDir, "kustomize", "edit", "set", "image", image); err != nil {
+				return nil, err
+			}
+		}
+	}
+	cmd := exec.Command("kustomize", "build", repoDir)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	return splitYAML(out)
+}
+
+func runIn(dir, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	return cmd.Run()
+}
@@ -0,0 +1,138 @@
+package application
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Logs streams the logs of the containers of the pods managed by an application. When
+// q.PodName is empty, every pod carrying the application's instance label
+// (v1alpha1.ResourceInstanceLabelKey) is tailed and multiplexed onto the same stream.
+// Per-pod tailers run concurrently, but every line is funneled through a single channel so
+// only one goroutine ever calls ws.Send, since gRPC streams do not support concurrent sends.
+func (s *Server) Logs(q *ApplicationLogsQuery, ws ApplicationService_LogsServer) error {
+	a, err := s.appclientset.ArgoprojV1alpha1().Applications(s.ns).Get(q.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	pods, err := s.podsForApplication(a, q.PodName)
+	if err != nil {
+		return err
+	}
+
+	entries := make(chan *LogEntry)
+	errCh := make(chan error, len(pods))
+	var wg sync.WaitGroup
+	wg.Add(len(pods))
+	for _, pod := range pods {
+		go func(pod string) {
+			defer wg.Done()
+			errCh <- s.streamPodLogs(ws.Context(), a.Status.ComparisonResult.Namespace, pod, q, entries)
+		}(pod)
+	}
+	go func() {
+		wg.Wait()
+		close(entries)
+	}()
+
+	for entry := range entries {
+		if err := ws.Send(entry); err != nil {
+			return err
+		}
+	}
+
+	var firstErr error
+	for range pods {
+		if err := <-errCh; err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// podsForApplication returns the pods carrying the application's v1alpha1.ResourceInstanceLabelKey
+// label in the application's ComparisonResult namespace, optionally restricted to a single pod
+// name.
+func (s *Server) podsForApplication(a *v1alpha1.Application, podName string) ([]string, error) {
+	if podName != "" {
+		return []string{podName}, nil
+	}
+	selector := v1alpha1.ResourceInstanceLabelKey + "=" + a.Name
+	podList, err := s.kubeclientset.CoreV1().Pods(a.Status.ComparisonResult.Namespace).List(metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	pods := make([]string, 0, len(podList.Items))
+	for _, pod := range podList.Items {
+		pods = append(pods, pod.Name)
+	}
+	return pods, nil
+}
+
+// streamPodLogs tails a single pod's logs, sending each line to entries. It never touches
+// the gRPC stream directly so it can safely run concurrently with tailers for other pods.
+func (s *Server) streamPodLogs(ctx context.Context, namespace, pod string, q *ApplicationLogsQuery, entries chan<- *LogEntry) error {
+	opts := &corev1.PodLogOptions{
+		Container:  q.Container,
+		Follow:     q.Follow,
+		Timestamps: true,
+	}
+	if q.TailLines > 0 {
+		opts.TailLines = &q.TailLines
+	}
+	if q.SinceSeconds > 0 {
+		opts.SinceSeconds = &q.SinceSeconds
+	}
+	stream, err := s.kubeclientset.CoreV1().Pods(namespace).GetLogs(pod, opts).Stream()
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	scanner := bufio.NewScanner(stream)
+	for scanner.Scan() {
+		entry := &LogEntry{Content: scanner.Text(), Pod: pod, Container: q.Container}
+		if ts, rest, ok := splitTimestamp(entry.Content); ok {
+			entry.Content = rest
+			entry.TimeStamp = ts
+		}
+		select {
+		case entries <- entry:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if err := scanner.Err(); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// splitTimestamp splits a log line prefixed with the RFC3339Nano timestamp that
+// corev1.PodLogOptions.Timestamps causes the kubelet to prepend (e.g.
+// "2026-07-30T12:00:00.000000000Z the actual log line") into the parsed timestamp and the
+// remaining content. ok is false, and line is returned unchanged, if line has no such prefix.
+func splitTimestamp(line string) (ts *timestamp.Timestamp, content string, ok bool) {
+	prefix, rest, found := strings.Cut(line, " ")
+	if !found {
+		return nil, line, false
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, prefix)
+	if err != nil {
+		return nil, line, false
+	}
+	ts, err = ptypes.TimestampProto(parsed)
+	if err != nil {
+		return nil, line, false
+	}
+	return ts, rest, true
+}
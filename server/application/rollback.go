@@ -0,0 +1,52 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Rollback re-syncs an application using the source recorded in one of its RevisionHistory
+// entries, rather than the application's current target source.
+func (s *Server) Rollback(ctx context.Context, req *RollbackRequest) (*ApplicationSyncResponse, error) {
+	a, err := s.appclientset.ArgoprojV1alpha1().Applications(s.ns).Get(req.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var rollbackTo *v1alpha1.RevisionHistory
+	for i := range a.Status.History {
+		if a.Status.History[i].ID == req.Id {
+			rollbackTo = &a.Status.History[i]
+			break
+		}
+	}
+	if rollbackTo == nil {
+		return nil, fmt.Errorf("application %s has no history entry with id %d", req.Name, req.Id)
+	}
+
+	// pin the recorded source to the revision that was actually deployed
+	source := rollbackTo.Source
+	source.TargetRevision = rollbackTo.Revision
+	manifestInfo, err := s.repoClientset.GenerateManifest(source)
+	if err != nil {
+		return nil, err
+	}
+	res := ApplicationSyncResponse{}
+	for _, m := range manifestInfo.Resources {
+		if err := s.kubeUtil.ApplyResource(ctx, a.Status.ComparisonResult.Server, a.Status.ComparisonResult.Namespace, m.GetName(), m.GetKind(), req.DryRun); err != nil {
+			res.Resources = append(res.Resources, &ResourceDetails{Name: m.GetName(), Kind: m.GetKind(), Message: err.Error()})
+			continue
+		}
+		res.Resources = append(res.Resources, &ResourceDetails{Name: m.GetName(), Kind: m.GetKind(), Message: "synced"})
+	}
+	res.Message = fmt.Sprintf("rolled back to revision %s", rollbackTo.Revision)
+	if !req.DryRun {
+		a.Status.AppendHistory(source, rollbackTo.Revision)
+		if _, err := s.appclientset.ArgoprojV1alpha1().Applications(s.ns).Update(a); err != nil {
+			return nil, err
+		}
+	}
+	return &res, nil
+}
@@ -0,0 +1,154 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SyncStream syncs an application, emitting a ResourceStatus for every resource as it
+// transitions between Pending, Running and Synced/Failed. If the caller's context is
+// canceled (e.g. the CLI sends SIGINT) before the sync completes, any resources not yet
+// applied are skipped and the stream is closed after reporting the next unapplied resource
+// as Failed with the message "Aborted". On a successful, non-dry-run sync, a RevisionHistory entry is recorded on
+// the application so `app history`/`app rollback` have something to work with.
+func (s *Server) SyncStream(q *ApplicationSyncRequest, ws ApplicationService_SyncStreamServer) error {
+	ctx := ws.Context()
+	a, err := s.appclientset.ArgoprojV1alpha1().Applications(s.ns).Get(q.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	resources, revision, err := s.manifestsToSync(ctx, a, q.Prune)
+	if err != nil {
+		return err
+	}
+
+	if q.TimeoutSeconds > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(q.TimeoutSeconds)*time.Second)
+		defer cancel()
+	}
+
+	failed := false
+	for _, res := range resources {
+		select {
+		case <-ctx.Done():
+			return ws.Send(&ResourceStatus{Name: res.Name, Kind: res.Kind, Phase: ResourcePhase_Failed, Message: "Aborted"})
+		default:
+		}
+		if err := ws.Send(&ResourceStatus{Name: res.Name, Kind: res.Kind, Phase: ResourcePhase_Running}); err != nil {
+			return err
+		}
+		phase, message := s.applyResource(ctx, a, res, q.DryRun)
+		if err := ws.Send(&ResourceStatus{Name: res.Name, Kind: res.Kind, Phase: phase, Message: message}); err != nil {
+			return err
+		}
+		if phase != ResourcePhase_Synced {
+			failed = true
+		}
+	}
+
+	if q.DryRun || failed {
+		return nil
+	}
+	if err := s.recordHistory(a, revision); err != nil {
+		return err
+	}
+	if q.Wait {
+		return s.waitForHealthy(ctx, ws, a)
+	}
+	return nil
+}
+
+// waitForHealthy blocks, polling the Application's health, until it reports Healthy or
+// the context is canceled (by --timeout or Ctrl-C), emitting a synthetic "Application"
+// ResourceStatus for the wait itself.
+func (s *Server) waitForHealthy(ctx context.Context, ws ApplicationService_SyncStreamServer, a *v1alpha1.Application) error {
+	if err := ws.Send(&ResourceStatus{Name: a.Name, Kind: "Application", Phase: ResourcePhase_Running, Message: "waiting for healthy"}); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ws.Send(&ResourceStatus{Name: a.Name, Kind: "Application", Phase: ResourcePhase_Failed, Message: "timed out waiting for healthy"})
+		case <-ticker.C:
+			current, err := s.appclientset.ArgoprojV1alpha1().Applications(s.ns).Get(a.Name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			if current.Status.Health.Status == v1alpha1.HealthStatusHealthy {
+				return ws.Send(&ResourceStatus{Name: a.Name, Kind: "Application", Phase: ResourcePhase_Synced, Message: "healthy"})
+			}
+		}
+	}
+}
+
+// recordHistory appends a RevisionHistory entry for the revision just synced and
+// persists the updated application status.
+func (s *Server) recordHistory(a *v1alpha1.Application, revision string) error {
+	a.Status.AppendHistory(a.Spec.Source, revision)
+	_, err := s.appclientset.ArgoprojV1alpha1().Applications(s.ns).Update(a)
+	return err
+}
+
+// syncResource is one resource targeted by a sync. Prune is set when the resource is a live
+// cluster resource that is no longer part of the target manifest set and should be deleted
+// instead of applied.
+type syncResource struct {
+	Name  string
+	Kind  string
+	Prune bool
+}
+
+// manifestsToSync resolves the manifests that need to be applied to bring the application in
+// line with the target revision, using the same repo-server manifest generation as the
+// non-streaming Sync RPC. When prune is true, it also diffs the live resources owned by the
+// application (selected via v1alpha1.ResourceInstanceLabelKey) against that target set and
+// appends the orphans, marked for deletion. It returns the resolved revision (e.g. the git
+// commit SHA) the manifests were generated from.
+func (s *Server) manifestsToSync(ctx context.Context, a *v1alpha1.Application, prune bool) ([]syncResource, string, error) {
+	manifestInfo, err := s.repoClientset.GenerateManifest(a.Spec.Source)
+	if err != nil {
+		return nil, "", err
+	}
+	resources := make([]syncResource, 0, len(manifestInfo.Resources))
+	target := make(map[string]bool, len(manifestInfo.Resources))
+	for _, r := range manifestInfo.Resources {
+		resources = append(resources, syncResource{Name: r.GetName(), Kind: r.GetKind()})
+		target[r.GetKind()+"/"+r.GetName()] = true
+	}
+
+	if prune {
+		selector := v1alpha1.ResourceInstanceLabelKey + "=" + a.Name
+		live, err := s.kubeUtil.ListResources(ctx, a.Status.ComparisonResult.Server, a.Status.ComparisonResult.Namespace, selector)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, r := range live {
+			if !target[r.GetKind()+"/"+r.GetName()] {
+				resources = append(resources, syncResource{Name: r.GetName(), Kind: r.GetKind(), Prune: true})
+			}
+		}
+	}
+
+	return resources, manifestInfo.Revision, nil
+}
+
+// applyResource applies (or, if res.Prune is set, deletes) a single resource against the
+// application's target cluster/namespace, returning its terminal phase.
+func (s *Server) applyResource(ctx context.Context, a *v1alpha1.Application, res syncResource, dryRun bool) (ResourcePhase, string) {
+	if res.Prune {
+		if err := s.kubeUtil.DeleteResource(ctx, a.Status.ComparisonResult.Server, a.Status.ComparisonResult.Namespace, res.Name, res.Kind, dryRun); err != nil {
+			return ResourcePhase_Failed, err.Error()
+		}
+		return ResourcePhase_Synced, "pruned"
+	}
+	if err := s.kubeUtil.ApplyResource(ctx, a.Status.ComparisonResult.Server, a.Status.ComparisonResult.Namespace, res.Name, res.Kind, dryRun); err != nil {
+		return ResourcePhase_Failed, err.Error()
+	}
+	return ResourcePhase_Synced, "synced"
+}
@@ -0,0 +1,99 @@
+// Package printers implements the `-o {yaml,json,wide,name}` output conventions shared
+// across `argocd app` subcommands.
+package printers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	argoappv1 "github.com/argoproj/argo-cd/pkg/apis/application/v1alpha1"
+	"github.com/ghodss/yaml"
+)
+
+// ValidFormats are the output formats accepted by the `-o` flag
+var ValidFormats = []string{"yaml", "json", "wide", "name"}
+
+// PrintApplication renders a single application to out in the given format. An empty
+// format renders the same human-readable detail the repo has always printed.
+func PrintApplication(out io.Writer, format string, app *argoappv1.Application) error {
+	switch format {
+	case "", "yaml":
+		bytes, err := yaml.Marshal(app)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(bytes))
+		return err
+	case "json":
+		bytes, err := json.MarshalIndent(app, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(bytes))
+		return err
+	case "name":
+		_, err := fmt.Fprintf(out, "application/%s\n", app.Name)
+		return err
+	default:
+		return fmt.Errorf("unknown output format: %s", format)
+	}
+}
+
+// PrintApplicationList renders an application list to out in the given format. An empty
+// format prints the repo's existing NAME/CLUSTER/NAMESPACE/STATUS table; "wide" adds
+// PROJECT/REPO/PATH/ENV/REVISION/HEALTH columns.
+func PrintApplicationList(out io.Writer, format string, apps []argoappv1.Application) error {
+	switch format {
+	case "json":
+		bytes, err := json.MarshalIndent(apps, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(bytes))
+		return err
+	case "yaml":
+		bytes, err := yaml.Marshal(apps)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(bytes))
+		return err
+	case "name":
+		for _, app := range apps {
+			if _, err := fmt.Fprintf(out, "application/%s\n", app.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "", "wide":
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		if format == "wide" {
+			fmt.Fprintln(w, "NAME\tCLUSTER\tNAMESPACE\tSTATUS\tPROJECT\tREPO\tPATH\tENV\tREVISION\tHEALTH")
+		} else {
+			fmt.Fprintln(w, "NAME\tCLUSTER\tNAMESPACE\tSTATUS")
+		}
+		for _, app := range apps {
+			if format == "wide" {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+					app.Name,
+					app.Status.ComparisonResult.Server,
+					app.Status.ComparisonResult.Namespace,
+					app.Status.ComparisonResult.Status,
+					app.Spec.Project,
+					app.Spec.Source.RepoURL,
+					app.Spec.Source.Path,
+					app.Spec.Source.Environment,
+					app.Status.ComparisonResult.Revision,
+					app.Status.Health.Status,
+				)
+			} else {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", app.Name, app.Status.ComparisonResult.Server, app.Status.ComparisonResult.Namespace, app.Status.ComparisonResult.Status)
+			}
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("unknown output format: %s", format)
+	}
+}